@@ -0,0 +1,83 @@
+// Command watcher runs a long-lived Blobstream verifier: it watches
+// Celestia for blobs posted under a namespace and verifies each one against
+// the Blobstream contract as soon as its attestation appears on Ethereum.
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"flag"
+	"log"
+	"net/http"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	tmhttp "github.com/tendermint/tendermint/rpc/client/http"
+
+	"github.com/kobakaku/blobstream-rollup-example/pkg/blobstreamwatcher"
+)
+
+func main() {
+	rpcEndpoint := flag.String("rpc", "tcp://consensus.lunaroasis.net:26657", "Celestia RPC endpoint")
+	evmRPC := flag.String("evm-rpc", "wss://eth-goerli.public.blastapi.io", "Ethereum RPC endpoint; must be ws:// or wss://, since the watcher subscribes to DataCommitmentStored events")
+	contractAddr := flag.String("contract", "0x046120E6c6C48C05627FB369756F5f44858950a5", "BlobstreamX contract address")
+	namespaceHex := flag.String("namespace", "", "hex-encoded namespace to watch (required)")
+	metricsAddr := flag.String("metrics-addr", ":2112", "address to serve Prometheus /metrics on")
+	flag.Parse()
+
+	if *namespaceHex == "" {
+		log.Fatal("❌ -namespace is required")
+	}
+	namespace, err := hex.DecodeString(*namespaceHex)
+	if err != nil {
+		log.Fatalf("❌ failed to decode -namespace: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Println("🔗 Establishing connection to Celestia...")
+	trpc, err := tmhttp.New(*rpcEndpoint, "/websocket")
+	if err != nil {
+		log.Fatalf("❌ failed to connect to Celestia: %v", err)
+	}
+	if err := trpc.Start(); err != nil {
+		log.Fatalf("❌ failed to start Celestia RPC client: %v", err)
+	}
+	defer trpc.Stop()
+
+	log.Println("🔗 Establishing connection to Ethereum client...")
+	if !strings.HasPrefix(*evmRPC, "ws://") && !strings.HasPrefix(*evmRPC, "wss://") {
+		log.Fatalf("❌ -evm-rpc must be a ws:// or wss:// endpoint: the watcher subscribes to DataCommitmentStored events, which requires a persistent connection an HTTP endpoint cannot provide")
+	}
+	ethClient, err := ethclient.Dial(*evmRPC)
+	if err != nil {
+		log.Fatalf("❌ failed to connect to Ethereum client: %v", err)
+	}
+	defer ethClient.Close()
+
+	go func() {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		log.Printf("📊 Serving metrics on %s/metrics\n", *metricsAddr)
+		if err := http.ListenAndServe(*metricsAddr, mux); err != nil {
+			log.Printf("⚠️ metrics server stopped: %v", err)
+		}
+	}()
+
+	watcher := &blobstreamwatcher.Watcher{
+		Trpc:         trpc,
+		EthClient:    ethClient,
+		ContractAddr: *contractAddr,
+		Namespace:    namespace,
+	}
+
+	if err := watcher.Run(ctx); err != nil && ctx.Err() == nil {
+		log.Fatalf("❌ watcher stopped: %v", err)
+	}
+
+	log.Println("👋 Watcher shut down.")
+}
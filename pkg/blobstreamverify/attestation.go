@@ -0,0 +1,179 @@
+package blobstreamverify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	wrapper "github.com/celestiaorg/blobstream-contracts/v4/wrappers/Blobstream.sol"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// eventScanChunkBlocks is the block span requested per FilterLogs call.
+// Ethereum JSON-RPC providers commonly cap how many blocks (or how many
+// results) a single eth_getLogs call may span.
+const eventScanChunkBlocks = 10_000
+
+// attestationRange identifies the data commitment attestation covering
+// [Start, End) of Celestia block heights under the given Nonce.
+type attestationRange struct {
+	Nonce uint64
+	Start uint64
+	End   uint64
+}
+
+func (r attestationRange) covers(height uint64) bool {
+	return height >= r.Start && height < r.End
+}
+
+var (
+	attestationCacheMu   sync.Mutex
+	attestationCache     []attestationRange
+	attestationCacheFile = filepath.Join(os.TempDir(), "blobstream-rollup-example-attestations.json")
+)
+
+// FindAttestationForHeight locates the data commitment attestation on the
+// Blobstream contract at contractAddr whose [startBlock, endBlock) window
+// covers celestiaHeight, returning its nonce and window bounds, by walking
+// the contract's DataCommitmentStored events backwards from the chain head.
+//
+// An earlier version of this function bisected the attestation nonce space
+// as a fallback for providers that reject a wide FilterLogs call, using
+// Celestia's state_dataCommitment RPC to confirm each candidate window. That
+// bisection assumed data commitment nonces are evenly spaced, which doesn't
+// hold: the nonce space is shared with ValidatorSetChanged attestations
+// (nonce 1 is always the initial validator set), so neighboring data
+// commitment nonces aren't a fixed distance apart and can't be found by
+// bisecting the nonce value alone. Rather than ship a bisection that can
+// silently miss a real attestation, this function only does the one scan
+// that's known correct; callers facing a provider with a narrow FilterLogs
+// cap should lower eventScanChunkBlocks instead.
+func FindAttestationForHeight(ctx context.Context, ethClient *ethclient.Client, contractAddr string, celestiaHeight uint64) (nonce, start, end uint64, err error) {
+	if r, ok := lookupCachedAttestation(celestiaHeight); ok {
+		return r.Nonce, r.Start, r.End, nil
+	}
+
+	loadAttestationCacheFromDisk()
+	if r, ok := lookupCachedAttestation(celestiaHeight); ok {
+		return r.Nonce, r.Start, r.End, nil
+	}
+
+	latestBlock, err := ethClient.BlockNumber(ctx)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to fetch latest Ethereum block: %w", err)
+	}
+
+	r, err := scanDataCommitmentEvents(ctx, ethClient, contractAddr, celestiaHeight, latestBlock)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to locate attestation for height %d: %w", celestiaHeight, err)
+	}
+
+	cacheAttestation(r)
+	saveAttestationCacheToDisk()
+
+	return r.Nonce, r.Start, r.End, nil
+}
+
+// scanDataCommitmentEvents walks the Blobstream contract's
+// DataCommitmentStored events backwards from latestBlock in
+// eventScanChunkBlocks-sized chunks, looking for the attestation whose
+// window covers celestiaHeight. Attestations are emitted in nonce order, so
+// the most recently emitted windows are also the most likely to cover a
+// recent height, making a backward walk the cheaper default.
+func scanDataCommitmentEvents(ctx context.Context, ethClient *ethclient.Client, contractAddr string, celestiaHeight, latestBlock uint64) (attestationRange, error) {
+	blobstreamWrapper, err := wrapper.NewWrappers(ethcmn.HexToAddress(contractAddr), ethClient)
+	if err != nil {
+		return attestationRange{}, fmt.Errorf("failed to fetch BlobstreamX contract: %w", err)
+	}
+
+	for to := latestBlock; ; {
+		from := uint64(0)
+		if to > eventScanChunkBlocks {
+			from = to - eventScanChunkBlocks
+		}
+
+		it, err := blobstreamWrapper.FilterDataCommitmentStored(&bind.FilterOpts{Start: from, End: &to, Context: ctx})
+		if err != nil {
+			return attestationRange{}, fmt.Errorf("failed to filter DataCommitmentStored logs: %w", err)
+		}
+
+		for it.Next() {
+			r := attestationRange{
+				Nonce: it.Event.ProofNonce.Uint64(),
+				Start: it.Event.StartBlock,
+				End:   it.Event.EndBlock,
+			}
+			if r.covers(celestiaHeight) {
+				it.Close()
+				return r, nil
+			}
+		}
+		if err := it.Error(); err != nil {
+			it.Close()
+			return attestationRange{}, fmt.Errorf("failed to iterate DataCommitmentStored logs: %w", err)
+		}
+		it.Close()
+
+		if from == 0 {
+			return attestationRange{}, fmt.Errorf("no attestation found covering height %d", celestiaHeight)
+		}
+		to = from
+	}
+}
+
+func lookupCachedAttestation(height uint64) (attestationRange, bool) {
+	attestationCacheMu.Lock()
+	defer attestationCacheMu.Unlock()
+
+	for _, r := range attestationCache {
+		if r.covers(height) {
+			return r, true
+		}
+	}
+	return attestationRange{}, false
+}
+
+func cacheAttestation(r attestationRange) {
+	attestationCacheMu.Lock()
+	defer attestationCacheMu.Unlock()
+
+	attestationCache = append(attestationCache, r)
+}
+
+// loadAttestationCacheFromDisk best-effort loads previously discovered
+// attestations from attestationCacheFile. A missing or corrupt cache file is
+// not an error; it just means lookups fall back to scanning on-chain.
+func loadAttestationCacheFromDisk() {
+	data, err := os.ReadFile(attestationCacheFile)
+	if err != nil {
+		return
+	}
+
+	var cached []attestationRange
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return
+	}
+
+	attestationCacheMu.Lock()
+	defer attestationCacheMu.Unlock()
+	attestationCache = append(attestationCache, cached...)
+}
+
+// saveAttestationCacheToDisk best-effort persists the in-memory cache to
+// attestationCacheFile so that future processes skip the on-chain lookup
+// entirely.
+func saveAttestationCacheToDisk() {
+	attestationCacheMu.Lock()
+	data, err := json.Marshal(attestationCache)
+	attestationCacheMu.Unlock()
+	if err != nil {
+		return
+	}
+
+	_ = os.WriteFile(attestationCacheFile, data, 0o644)
+}
@@ -0,0 +1,165 @@
+// Package blobstreamverify builds and verifies Blobstream blob inclusion
+// proofs, so that a rollup's settlement or fraud-proof pipeline can confirm
+// that a blob posted to Celestia was correctly attested on Ethereum.
+package blobstreamverify
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	wrapper "github.com/celestiaorg/blobstream-contracts/v4/wrappers/Blobstream.sol"
+	"github.com/celestiaorg/celestia-app/pkg/square"
+	bstypes "github.com/celestiaorg/celestia-app/x/blob/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/rpc/client/http"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+// BlobInclusionProof bundles everything needed to prove, on Ethereum, that a
+// blob posted to Celestia at Height was included in the data root attested
+// under DataCommitmentNonce.
+type BlobInclusionProof struct {
+	// Height is the Celestia block height containing the blob.
+	Height int64
+	// DataRoot is the block's data root that the proof is anchored to.
+	DataRoot []byte
+	// ShareData is the raw shares that make up the blob's share range.
+	ShareData [][]byte
+	// ShareProof proves that ShareData is included in the block's data square.
+	ShareProof bstypes.ShareProof
+	// DataRootInclusionProof proves that DataRoot is included in the data
+	// commitment attested on Ethereum under DataCommitmentNonce.
+	DataRootInclusionProof merkle.Proof
+	// DataCommitmentNonce is the nonce of the data commitment attestation
+	// that covers Height.
+	DataCommitmentNonce uint64
+}
+
+// BuildBlobInclusionProof fetches the transaction and block for txHash from
+// trpc and assembles a BlobInclusionProof for the blob at blobIndex within
+// that transaction. The covering data commitment attestation is discovered
+// automatically from the Blobstream contract at contractAddr.
+func BuildBlobInclusionProof(ctx context.Context, trpc *http.HTTP, ethClient *ethclient.Client, contractAddr, txHash string, blobIndex uint32) (*BlobInclusionProof, error) {
+	tx, block, shareProof, err := fetchBlobShareProof(ctx, trpc, txHash, blobIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, start, end, err := FindAttestationForHeight(ctx, ethClient, contractAddr, uint64(tx.Height))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find attestation covering height %d: %w", tx.Height, err)
+	}
+
+	return buildBlobInclusionProof(ctx, trpc, tx, block, shareProof, nonce, start, end)
+}
+
+// BuildBlobInclusionProofForAttestation is like BuildBlobInclusionProof, but
+// for a caller that has already observed the covering DataCommitmentStored
+// event — e.g. a long-running watcher verifying many blobs against the same
+// attestation — and so can supply its nonce and [start, end) window
+// directly instead of rediscovering it with a fresh FindAttestationForHeight
+// scan per blob.
+func BuildBlobInclusionProofForAttestation(ctx context.Context, trpc *http.HTTP, txHash string, blobIndex uint32, nonce, start, end uint64) (*BlobInclusionProof, error) {
+	tx, block, shareProof, err := fetchBlobShareProof(ctx, trpc, txHash, blobIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildBlobInclusionProof(ctx, trpc, tx, block, shareProof, nonce, start, end)
+}
+
+// fetchBlobShareProof fetches the transaction and block for txHash from trpc
+// and proves inclusion of the blob at blobIndex within that transaction's
+// share range.
+func fetchBlobShareProof(ctx context.Context, trpc *http.HTTP, txHash string, blobIndex uint32) (*coretypes.ResultTx, *coretypes.ResultBlock, bstypes.ShareProof, error) {
+	txHashBz, err := hex.DecodeString(txHash)
+	if err != nil {
+		return nil, nil, bstypes.ShareProof{}, fmt.Errorf("failed to decode transaction hash: %w", err)
+	}
+
+	tx, err := trpc.Tx(ctx, txHashBz, true)
+	if err != nil {
+		return nil, nil, bstypes.ShareProof{}, fmt.Errorf("failed to fetch transaction: %w", err)
+	}
+
+	block, err := trpc.Block(ctx, &tx.Height)
+	if err != nil {
+		return nil, nil, bstypes.ShareProof{}, fmt.Errorf("failed to fetch block: %w", err)
+	}
+
+	// Calculate the range of shares that the blob occupied in the block.
+	blobShareRange, err := square.BlobShareRange(block.Block.Txs.ToSliceOfBytes(), int(tx.Index), int(blobIndex), block.Block.Version.App)
+	if err != nil {
+		return nil, nil, bstypes.ShareProof{}, fmt.Errorf("failed to get blob share range: %w", err)
+	}
+
+	shareProof, err := trpc.ProveShares(ctx, uint64(tx.Height), uint64(blobShareRange.Start), uint64(blobShareRange.End))
+	if err != nil {
+		return nil, nil, bstypes.ShareProof{}, fmt.Errorf("failed to get share proofs: %w", err)
+	}
+
+	if !shareProof.VerifyProof() {
+		return nil, nil, bstypes.ShareProof{}, fmt.Errorf("failed to verify share proofs")
+	}
+
+	return tx, block, shareProof, nil
+}
+
+// buildBlobInclusionProof assembles a BlobInclusionProof for shareProof
+// against the data commitment attestation identified by nonce and
+// [start, end).
+func buildBlobInclusionProof(ctx context.Context, trpc *http.HTTP, tx *coretypes.ResultTx, block *coretypes.ResultBlock, shareProof bstypes.ShareProof, nonce, start, end uint64) (*BlobInclusionProof, error) {
+	dcProof, err := trpc.DataRootInclusionProof(ctx, uint64(tx.Height), start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate data root inclusion proof: %w", err)
+	}
+
+	return &BlobInclusionProof{
+		Height:                 tx.Height,
+		DataRoot:               block.Block.DataHash,
+		ShareData:              shareProof.Data,
+		ShareProof:             shareProof,
+		DataRootInclusionProof: dcProof.Proof,
+		DataCommitmentNonce:    nonce,
+	}, nil
+}
+
+// VerifyBlobInclusion checks proof against the Blobstream contract deployed
+// at contractAddr, confirming that proof's data root was attested on
+// Ethereum under its data commitment nonce.
+func VerifyBlobInclusion(ctx context.Context, ethClient *ethclient.Client, contractAddr string, proof *BlobInclusionProof) (bool, error) {
+	return verifyAttestation(ctx, ethClient, contractAddr, proof.Height, proof.DataRoot, proof.DataRootInclusionProof, proof.DataCommitmentNonce)
+}
+
+// verifyAttestation checks a data root inclusion proof against the
+// Blobstream contract deployed at contractAddr. Both VerifyBlobInclusion and
+// VerifyTxInclusion reduce to this same on-chain check, since it only cares
+// about the data root, not what shares it committed to.
+func verifyAttestation(ctx context.Context, ethClient *ethclient.Client, contractAddr string, height int64, dataRoot []byte, dcProof merkle.Proof, nonce uint64) (bool, error) {
+	blobstreamWrapper, err := wrapper.NewWrappers(ethcmn.HexToAddress(contractAddr), ethClient)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch BlobstreamX contract: %w", err)
+	}
+
+	tuple := wrapper.DataRootTuple{
+		Height:   big.NewInt(height),
+		DataRoot: *(*[32]byte)(dataRoot),
+	}
+
+	sideNodes := make([][32]byte, len(dcProof.Aunts))
+	for i, aunt := range dcProof.Aunts {
+		sideNodes[i] = *(*[32]byte)(aunt)
+	}
+	wrappedProof := wrapper.BinaryMerkleProof{
+		SideNodes: sideNodes,
+		Key:       big.NewInt(dcProof.Index),
+		NumLeaves: big.NewInt(dcProof.Total),
+	}
+
+	return blobstreamWrapper.VerifyAttestation(&bind.CallOpts{Context: ctx}, big.NewInt(int64(nonce)), tuple, wrappedProof)
+}
@@ -0,0 +1,276 @@
+package blobstreamverify
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	bstypes "github.com/celestiaorg/celestia-app/x/blob/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/tendermint/tendermint/crypto/merkle"
+)
+
+// sharesProofABI describes the DAVerifier.SharesProof struct's fields from
+// blobstream-contracts, so its arguments can be ABI-encoded without a full
+// contract binding for it. It's declared as a fake method purely so the
+// standard abi.JSON parser can build the argument types for us; only its
+// Inputs (not a selector) are ever packed. Namespace (both the proof's own
+// namespace and each NamespaceNode's Min/Max) is the contract's Namespace
+// tuple (bytes1 version, bytes28 id), not a flat bytes29.
+const sharesProofABI = `[{
+	"name": "encode",
+	"type": "function",
+	"inputs": [{
+		"name": "proof",
+		"type": "tuple",
+		"components": [
+			{"name": "Data", "type": "bytes[]"},
+			{"name": "ShareProofs", "type": "tuple[]", "components": [
+				{"name": "BeginKey", "type": "uint256"},
+				{"name": "EndKey", "type": "uint256"},
+				{"name": "Nodes", "type": "tuple[]", "components": [
+					{"name": "Min", "type": "tuple", "components": [
+						{"name": "Version", "type": "bytes1"},
+						{"name": "Id", "type": "bytes28"}
+					]},
+					{"name": "Max", "type": "tuple", "components": [
+						{"name": "Version", "type": "bytes1"},
+						{"name": "Id", "type": "bytes28"}
+					]},
+					{"name": "Digest", "type": "bytes32"}
+				]}
+			]},
+			{"name": "Namespace", "type": "tuple", "components": [
+				{"name": "Version", "type": "bytes1"},
+				{"name": "Id", "type": "bytes28"}
+			]},
+			{"name": "RowRoots", "type": "tuple[]", "components": [
+				{"name": "Min", "type": "tuple", "components": [
+					{"name": "Version", "type": "bytes1"},
+					{"name": "Id", "type": "bytes28"}
+				]},
+				{"name": "Max", "type": "tuple", "components": [
+					{"name": "Version", "type": "bytes1"},
+					{"name": "Id", "type": "bytes28"}
+				]},
+				{"name": "Digest", "type": "bytes32"}
+			]},
+			{"name": "RowProofs", "type": "tuple[]", "components": [
+				{"name": "SideNodes", "type": "bytes32[]"},
+				{"name": "Key", "type": "uint256"},
+				{"name": "NumLeaves", "type": "uint256"}
+			]},
+			{"name": "AttestationProof", "type": "tuple", "components": [
+				{"name": "TupleRootNonce", "type": "uint256"},
+				{"name": "Tuple", "type": "tuple", "components": [
+					{"name": "Height", "type": "uint256"},
+					{"name": "DataRoot", "type": "bytes32"}
+				]},
+				{"name": "Proof", "type": "tuple", "components": [
+					{"name": "SideNodes", "type": "bytes32[]"},
+					{"name": "Key", "type": "uint256"},
+					{"name": "NumLeaves", "type": "uint256"}
+				]}
+			]}
+		]
+	}]
+}]`
+
+// namespaceCalldata is the contract's Namespace tuple: a one-byte version
+// followed by a 28-byte ID.
+type namespaceCalldata struct {
+	Version [1]byte
+	Id      [28]byte
+}
+
+type namespaceNodeCalldata struct {
+	Min    namespaceCalldata
+	Max    namespaceCalldata
+	Digest [32]byte
+}
+
+type binaryMerkleProofCalldata struct {
+	SideNodes [][32]byte
+	Key       *big.Int
+	NumLeaves *big.Int
+}
+
+type dataRootTupleCalldata struct {
+	Height   *big.Int
+	DataRoot [32]byte
+}
+
+type attestationProofCalldata struct {
+	TupleRootNonce *big.Int
+	Tuple          dataRootTupleCalldata
+	Proof          binaryMerkleProofCalldata
+}
+
+type namespaceMerkleMultiproofCalldata struct {
+	BeginKey *big.Int
+	EndKey   *big.Int
+	Nodes    []namespaceNodeCalldata
+}
+
+type sharesProofCalldata struct {
+	Data             [][]byte
+	ShareProofs      []namespaceMerkleMultiproofCalldata
+	Namespace        namespaceCalldata
+	RowRoots         []namespaceNodeCalldata
+	RowProofs        []binaryMerkleProofCalldata
+	AttestationProof attestationProofCalldata
+}
+
+// shareSubProof is the share range and NMT sibling nodes proving one
+// contiguous span of shares into its row roots, independent of whichever
+// concrete NMT proof type celestia-app's ShareProof carries it as.
+type shareSubProof struct {
+	start, end int
+	nodes      [][]byte
+}
+
+// EncodeSharesProofCalldata ABI-encodes shareProof, dcProof, dataRoot,
+// height and nonce exactly as the Blobstream DAVerifier library's
+// SharesProof struct expects, so the result can be submitted directly to a
+// rollup settlement contract.
+func EncodeSharesProofCalldata(shareProof bstypes.ShareProof, dcProof merkle.Proof, dataRoot []byte, height, nonce uint64) ([]byte, error) {
+	shareProofs := make([]shareSubProof, len(shareProof.ShareProofs))
+	for i, p := range shareProof.ShareProofs {
+		shareProofs[i] = shareSubProof{start: int(p.Start), end: int(p.End), nodes: p.Nodes}
+	}
+
+	rowProofs := make([]merkle.Proof, len(shareProof.RowProof.Proofs))
+	for i, p := range shareProof.RowProof.Proofs {
+		rowProofs[i] = *p
+	}
+
+	return encodeSharesProofCalldata(
+		shareProof.Data,
+		shareProofs,
+		shareProof.NamespaceVersion,
+		shareProof.NamespaceId,
+		shareProof.RowProof.RowRoots,
+		rowProofs,
+		dcProof,
+		dataRoot,
+		height,
+		nonce,
+	)
+}
+
+// encodeSharesProofCalldata does the actual ABI encoding, taking only
+// plain Go types so it can be exercised directly in tests without having to
+// construct celestia-app's ShareProof and its nested NMT proof types.
+func encodeSharesProofCalldata(
+	data [][]byte,
+	shareProofs []shareSubProof,
+	namespaceVersion uint8,
+	namespaceID []byte,
+	rowRoots [][]byte,
+	rowProofs []merkle.Proof,
+	dcProof merkle.Proof,
+	dataRoot []byte,
+	height, nonce uint64,
+) ([]byte, error) {
+	namespace, err := toNamespaceCalldata(namespaceVersion, namespaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode namespace: %w", err)
+	}
+
+	multiproofs := make([]namespaceMerkleMultiproofCalldata, len(shareProofs))
+	for i, p := range shareProofs {
+		nodes, err := toNamespaceNodes(p.nodes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode share proof %d: %w", i, err)
+		}
+		multiproofs[i] = namespaceMerkleMultiproofCalldata{
+			BeginKey: big.NewInt(int64(p.start)),
+			EndKey:   big.NewInt(int64(p.end)),
+			Nodes:    nodes,
+		}
+	}
+
+	rowRootNodes, err := toNamespaceNodes(rowRoots)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode row roots: %w", err)
+	}
+
+	rowProofsCalldata := make([]binaryMerkleProofCalldata, len(rowProofs))
+	for i, p := range rowProofs {
+		rowProofsCalldata[i] = toBinaryMerkleProofCalldata(p)
+	}
+
+	var root [32]byte
+	if len(dataRoot) != 32 {
+		return nil, fmt.Errorf("unexpected data root length %d, want 32", len(dataRoot))
+	}
+	copy(root[:], dataRoot)
+
+	proof := sharesProofCalldata{
+		Data:        data,
+		ShareProofs: multiproofs,
+		Namespace:   namespace,
+		RowRoots:    rowRootNodes,
+		RowProofs:   rowProofsCalldata,
+		AttestationProof: attestationProofCalldata{
+			TupleRootNonce: new(big.Int).SetUint64(nonce),
+			Tuple: dataRootTupleCalldata{
+				Height:   new(big.Int).SetUint64(height),
+				DataRoot: root,
+			},
+			Proof: toBinaryMerkleProofCalldata(dcProof),
+		},
+	}
+
+	daVerifierABI, err := abi.JSON(strings.NewReader(sharesProofABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DAVerifier ABI: %w", err)
+	}
+
+	// Pack only the method's input arguments (no 4-byte selector): the
+	// result is bare struct-encoded calldata, not a call to our fake
+	// "encode" method.
+	return daVerifierABI.Methods["encode"].Inputs.Pack(proof)
+}
+
+// toNamespaceNodes splits each 90-byte namespaced hash (min namespace || max
+// namespace || digest) returned by the NMT into a NamespaceNode tuple, with
+// Min/Max further split into their version byte and 28-byte ID.
+func toNamespaceNodes(raw [][]byte) ([]namespaceNodeCalldata, error) {
+	nodes := make([]namespaceNodeCalldata, len(raw))
+	for i, b := range raw {
+		if len(b) != 90 {
+			return nil, fmt.Errorf("node %d: unexpected namespaced hash length %d, want 90", i, len(b))
+		}
+		nodes[i].Min.Version[0] = b[0]
+		copy(nodes[i].Min.Id[:], b[1:29])
+		nodes[i].Max.Version[0] = b[29]
+		copy(nodes[i].Max.Id[:], b[30:58])
+		copy(nodes[i].Digest[:], b[58:90])
+	}
+	return nodes, nil
+}
+
+// toNamespaceCalldata builds the contract's Namespace tuple from the
+// version and 28-byte ID celestia-app tracks separately on a ShareProof.
+func toNamespaceCalldata(version uint8, id []byte) (namespaceCalldata, error) {
+	if len(id) != 28 {
+		return namespaceCalldata{}, fmt.Errorf("unexpected namespace id length %d, want 28", len(id))
+	}
+	var ns namespaceCalldata
+	ns.Version[0] = version
+	copy(ns.Id[:], id)
+	return ns, nil
+}
+
+func toBinaryMerkleProofCalldata(p merkle.Proof) binaryMerkleProofCalldata {
+	sideNodes := make([][32]byte, len(p.Aunts))
+	for i, aunt := range p.Aunts {
+		sideNodes[i] = *(*[32]byte)(aunt)
+	}
+	return binaryMerkleProofCalldata{
+		SideNodes: sideNodes,
+		Key:       big.NewInt(p.Index),
+		NumLeaves: big.NewInt(p.Total),
+	}
+}
@@ -0,0 +1,145 @@
+package blobstreamverify
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/app/encoding"
+	"github.com/celestiaorg/celestia-app/pkg/square"
+	bstypes "github.com/celestiaorg/celestia-app/x/blob/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/rpc/client/http"
+)
+
+// PFBInfo describes the MsgPayForBlobs carried by the transaction a
+// TxInclusionProof attests to.
+type PFBInfo struct {
+	// Signer is the bech32 address that signed the PFB.
+	Signer string
+	// Namespaces are the namespaces of the blobs the PFB pays for.
+	Namespaces [][]byte
+	// ShareCommitments are the blob share commitments the PFB pays for.
+	ShareCommitments [][]byte
+}
+
+// TxInclusionProof bundles everything needed to prove, on Ethereum, that a
+// PFB transaction itself (as opposed to the blob it pays for) was included
+// in the data root attested under DataCommitmentNonce.
+type TxInclusionProof struct {
+	// Height is the Celestia block height containing the transaction.
+	Height int64
+	// DataRoot is the block's data root that the proof is anchored to.
+	DataRoot []byte
+	// ShareData is the raw shares that make up the PFB transaction's share range.
+	ShareData [][]byte
+	// ShareProof proves that ShareData is included in the block's data square.
+	ShareProof bstypes.ShareProof
+	// DataRootInclusionProof proves that DataRoot is included in the data
+	// commitment attested on Ethereum under DataCommitmentNonce.
+	DataRootInclusionProof merkle.Proof
+	// DataCommitmentNonce is the nonce of the data commitment attestation
+	// that covers Height.
+	DataCommitmentNonce uint64
+	// PFB describes the MsgPayForBlobs carried by the proven transaction.
+	PFB PFBInfo
+}
+
+// BuildTxInclusionProof fetches the transaction and block for txHash from
+// trpc and assembles a TxInclusionProof for the PFB transaction shares
+// themselves, as distinct from the blob they pay for. The covering data
+// commitment attestation is discovered automatically from the Blobstream
+// contract at contractAddr.
+func BuildTxInclusionProof(ctx context.Context, trpc *http.HTTP, ethClient *ethclient.Client, contractAddr, txHash string) (*TxInclusionProof, error) {
+	txHashBz, err := hex.DecodeString(txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction hash: %w", err)
+	}
+
+	tx, err := trpc.Tx(ctx, txHashBz, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch transaction: %w", err)
+	}
+
+	block, err := trpc.Block(ctx, &tx.Height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch block: %w", err)
+	}
+
+	pfb, err := DecodePFB(tx.Tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode PFB transaction: %w", err)
+	}
+
+	// Calculate the range of shares that the PFB transaction itself (without
+	// its blob) occupied in the block.
+	txShareRange, err := square.TxShareRange(block.Block.Txs.ToSliceOfBytes(), int(tx.Index), block.Block.Version.App)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tx share range: %w", err)
+	}
+
+	shareProof, err := trpc.ProveShares(ctx, uint64(tx.Height), uint64(txShareRange.Start), uint64(txShareRange.End))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get share proofs: %w", err)
+	}
+
+	if !shareProof.VerifyProof() {
+		return nil, fmt.Errorf("failed to verify share proofs")
+	}
+
+	nonce, start, end, err := FindAttestationForHeight(ctx, ethClient, contractAddr, uint64(tx.Height))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find attestation covering height %d: %w", tx.Height, err)
+	}
+
+	dcProof, err := trpc.DataRootInclusionProof(ctx, uint64(tx.Height), start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate data root inclusion proof: %w", err)
+	}
+
+	return &TxInclusionProof{
+		Height:                 tx.Height,
+		DataRoot:               block.Block.DataHash,
+		ShareData:              shareProof.Data,
+		ShareProof:             shareProof,
+		DataRootInclusionProof: dcProof.Proof,
+		DataCommitmentNonce:    nonce,
+		PFB:                    *pfb,
+	}, nil
+}
+
+// VerifyTxInclusion checks proof against the Blobstream contract deployed at
+// contractAddr, confirming that proof's data root was attested on Ethereum
+// under its data commitment nonce.
+func VerifyTxInclusion(ctx context.Context, ethClient *ethclient.Client, contractAddr string, proof *TxInclusionProof) (bool, error) {
+	return verifyAttestation(ctx, ethClient, contractAddr, proof.Height, proof.DataRoot, proof.DataRootInclusionProof, proof.DataCommitmentNonce)
+}
+
+// DecodePFB decodes the MsgPayForBlobs carried by the raw transaction bytes
+// txBz, so callers can inspect the namespace, signer, and blob commitments
+// being attested without re-parsing the transaction themselves.
+func DecodePFB(txBz []byte) (*PFBInfo, error) {
+	encCfg := encoding.MakeConfig(app.ModuleBasics)
+
+	sdkTx, err := encCfg.TxConfig.TxDecoder()(txBz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction: %w", err)
+	}
+
+	for _, msg := range sdkTx.GetMsgs() {
+		pfb, ok := msg.(*bstypes.MsgPayForBlobs)
+		if !ok {
+			continue
+		}
+
+		return &PFBInfo{
+			Signer:           pfb.Signer,
+			Namespaces:       pfb.Namespaces,
+			ShareCommitments: pfb.ShareCommitments,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("transaction does not contain a MsgPayForBlobs")
+}
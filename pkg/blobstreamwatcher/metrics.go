@@ -0,0 +1,48 @@
+package blobstreamwatcher
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	observedBlobs = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "blobstream",
+		Subsystem: "watcher",
+		Name:      "blobs_observed_total",
+		Help:      "Number of blobs observed in the watched namespace.",
+	})
+	verifiedBlobs = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "blobstream",
+		Subsystem: "watcher",
+		Name:      "blobs_verified_total",
+		Help:      "Number of observed blobs successfully verified against BlobstreamX.",
+	})
+	verificationFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "blobstream",
+		Subsystem: "watcher",
+		Name:      "blob_verification_failures_total",
+		Help:      "Number of observed blobs that failed proof construction or verification.",
+	})
+	verificationLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "blobstream",
+		Subsystem: "watcher",
+		Name:      "verification_latency_seconds",
+		Help:      "Time to build and verify a blob inclusion proof once its attestation is seen.",
+		Buckets:   prometheus.DefBuckets,
+	})
+	attestationLag = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "blobstream",
+		Subsystem: "watcher",
+		Name:      "attestation_lag_seconds",
+		Help:      "Time between a blob being observed on Celestia and its attestation appearing on Ethereum.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+	})
+)
+
+func init() {
+	prometheus.MustRegister(
+		observedBlobs,
+		verifiedBlobs,
+		verificationFailures,
+		verificationLatency,
+		attestationLag,
+	)
+}
@@ -3,128 +3,130 @@ package main
 import (
 	"context"
 	"encoding/hex"
+	"flag"
 	"fmt"
 	"log"
-	"math/big"
+	"os"
 
-	wrapper "github.com/celestiaorg/blobstream-contracts/v4/wrappers/Blobstream.sol"
-	"github.com/celestiaorg/celestia-app/pkg/square"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	ethcmn "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/rpc/client/http"
+
+	"github.com/kobakaku/blobstream-rollup-example/pkg/blobstreamverify"
 )
 
 const (
-	txHash                   = "4B122452FA679F15B458271512816B933803D5870919F67969B4D62221D70346"
-	blobIndex                = 0
-	rpcEndpoint              = "tcp://consensus.lunaroasis.net:26657"
-	evmRPC                   = "https://eth-goerli.public.blastapi.io"
-	contractAddr             = "0x046120E6c6C48C05627FB369756F5f44858950a5"
-	dataCommitmentStartBlock = 105001
-	dataCommitmentEndBlock   = 106001
-	dataCommitmentNonce      = 106
+	txHash       = "4B122452FA679F15B458271512816B933803D5870919F67969B4D62221D70346"
+	blobIndex    = 0
+	rpcEndpoint  = "tcp://consensus.lunaroasis.net:26657"
+	evmRPC       = "https://eth-goerli.public.blastapi.io"
+	contractAddr = "0x046120E6c6C48C05627FB369756F5f44858950a5"
 )
 
 func main() {
+	prove := flag.String("prove", "blob", "what to prove inclusion of: blob, tx, or both")
+	emitCalldata := flag.String("emit-calldata", "", "write ABI-encoded DAVerifier calldata for the blob proof to this path, or \"-\" for stdout")
+	flag.Parse()
+
 	log.Println("🐱 Starting the verification process...")
 
-	if err := verify(); err != nil {
+	if err := verify(*prove, *emitCalldata); err != nil {
 		log.Fatalf("❌ Verification process failed: %v", err)
 	}
 
 	log.Println("✅ Verification process completed successfully.")
 }
 
-func verify() error {
-	log.Println("🔍 Decoding transaction hash...")
-
-	txHashBz, err := hex.DecodeString(txHash)
-	if err != nil {
-		return fmt.Errorf("failed to decode transaction hash: %w", err)
-	}
+func verify(prove, emitCalldata string) error {
+	ctx := context.Background()
 
 	log.Println("🔗 Establishing connection to Celestia...")
 	trpc, err := http.New(rpcEndpoint, "/websocket")
 	if err != nil {
-		return fmt.Errorf("failed to connect to Celestia: %w", err)
+		return err
 	}
 	defer trpc.Stop()
 
-	ctx := context.Background()
-
-	log.Println("📦 Fetching transaction with decoded hash from Celestia...")
-	tx, err := trpc.Tx(ctx, txHashBz, true)
+	log.Println("🔗 Establishing connection to Ethereum client...")
+	ethClient, err := ethclient.Dial(evmRPC)
 	if err != nil {
-		return fmt.Errorf("failed to fetch transaction: %w", err)
+		return err
 	}
+	defer ethClient.Close()
 
-	log.Println("📦 Fetching block from Celestia...")
-	block, err := trpc.Block(ctx, &tx.Height)
-	if err != nil {
-		return fmt.Errorf("failed to fetch block: %w", err)
+	switch prove {
+	case "blob":
+		return verifyBlob(ctx, trpc, ethClient, emitCalldata)
+	case "tx":
+		return verifyTx(ctx, trpc, ethClient)
+	case "both":
+		if err := verifyBlob(ctx, trpc, ethClient, emitCalldata); err != nil {
+			return err
+		}
+		return verifyTx(ctx, trpc, ethClient)
+	default:
+		return fmt.Errorf("unknown -prove value %q: must be blob, tx, or both", prove)
 	}
+}
 
-	// Calculate the range of shares that the blob toccupied in the block
-	blobShareRange, err := square.BlobShareRange(block.Block.Txs.ToSliceOfBytes(), int(tx.Index), int(blobIndex), block.Block.Version.App)
+func verifyBlob(ctx context.Context, trpc *http.HTTP, ethClient *ethclient.Client, emitCalldata string) error {
+	log.Println("🔍 Building blob inclusion proof...")
+	proof, err := blobstreamverify.BuildBlobInclusionProof(ctx, trpc, ethClient, contractAddr, txHash, blobIndex)
 	if err != nil {
-		return fmt.Errorf("failed to get blob share range: %w", err)
+		return err
 	}
 
-	shareProofs, err := trpc.ProveShares(ctx, uint64(tx.Height), uint64(blobShareRange.Start), uint64(blobShareRange.End))
+	log.Println("🔍 Verifying blob inclusion on BlobstreamX contract...")
+	valid, err := blobstreamverify.VerifyBlobInclusion(ctx, ethClient, contractAddr, proof)
 	if err != nil {
-		return fmt.Errorf("failed to get share proofs: %w", err)
+		return err
 	}
 
-	log.Println("🔍 Verifying share proofs...")
-	if !shareProofs.VerifyProof() {
-		return fmt.Errorf("failed to verify share proofs: %w", err)
-	}
+	log.Printf("📝 Blob inclusion valid: %v\n", valid)
 
-	log.Println("🔍 Generating data root inclusion proof...")
-	dcProof, err := trpc.DataRootInclusionProof(ctx, uint64(tx.Height), dataCommitmentStartBlock, dataCommitmentEndBlock)
-	if err != nil {
-		return fmt.Errorf("failed to generate data root inclusion proof: %w", err)
+	if emitCalldata != "" {
+		if err := writeCalldata(proof, emitCalldata); err != nil {
+			return fmt.Errorf("failed to emit calldata: %w", err)
+		}
 	}
 
-	log.Println("🔗 Establishing connection to Ethereum client...")
-	ethClient, err := ethclient.Dial(evmRPC)
+	return nil
+}
+
+func verifyTx(ctx context.Context, trpc *http.HTTP, ethClient *ethclient.Client) error {
+	log.Println("🔍 Building PFB transaction inclusion proof...")
+	proof, err := blobstreamverify.BuildTxInclusionProof(ctx, trpc, ethClient, contractAddr, txHash)
 	if err != nil {
-		return fmt.Errorf("failed to connect to Ethereum client: %w", err)
+		return err
 	}
-	defer ethClient.Close()
 
-	log.Println("📦 Fetching BlobstreamX contract...")
-	contractAddress := ethcmn.HexToAddress(contractAddr)
-	blobstreamWrapper, err := wrapper.NewWrappers(contractAddress, ethClient)
+	log.Printf("📝 PFB signer=%s namespaces=%x\n", proof.PFB.Signer, proof.PFB.Namespaces)
+
+	log.Println("🔍 Verifying PFB transaction inclusion on BlobstreamX contract...")
+	valid, err := blobstreamverify.VerifyTxInclusion(ctx, ethClient, contractAddr, proof)
 	if err != nil {
-		return fmt.Errorf("failed to fetch BlobstreamX contract: %w", err)
+		return err
 	}
 
-	log.Println("🔍 Verifying data root inclusion on BlobstreamX contract...")
-	VerifyDataRootInclusion(blobstreamWrapper, tx.Height, dataCommitmentNonce, block.Block.DataHash, dcProof.Proof)
+	log.Printf("📝 Transaction inclusion valid: %v\n", valid)
 
 	return nil
 }
 
-func VerifyDataRootInclusion(blobstreamWrapper *wrapper.Wrappers, height int64, nonce int, dataRoot []byte,
-	proof merkle.Proof) (bool, error) {
-	tuple := wrapper.DataRootTuple{
-		Height:   big.NewInt(height),
-		DataRoot: *(*[32]byte)(dataRoot),
+// writeCalldata ABI-encodes proof as DAVerifier calldata and writes its hex
+// representation to path, or to stdout if path is "-".
+func writeCalldata(proof *blobstreamverify.BlobInclusionProof, path string) error {
+	calldata, err := blobstreamverify.EncodeSharesProofCalldata(proof.ShareProof, proof.DataRootInclusionProof, proof.DataRoot, uint64(proof.Height), proof.DataCommitmentNonce)
+	if err != nil {
+		return err
 	}
 
-	sideNodes := make([][32]byte, len(proof.Aunts))
-	for i, aunt := range proof.Aunts {
-		sideNodes[i] = *(*[32]byte)(aunt)
-	}
-	wrappedProof := wrapper.BinaryMerkleProof{
-		SideNodes: sideNodes,
-		Key:       big.NewInt(proof.Index),
-		NumLeaves: big.NewInt(proof.Total),
+	encoded := hex.EncodeToString(calldata)
+
+	if path == "-" {
+		log.Println("📝 DAVerifier calldata:")
+		fmt.Println(encoded)
+		return nil
 	}
 
-	valid, err := blobstreamWrapper.VerifyAttestation(&bind.CallOpts{}, big.NewInt(int64(nonce)), tuple, wrappedProof)
-	return valid, err
+	return os.WriteFile(path, []byte(encoded), 0o644)
 }